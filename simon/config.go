@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds the persistent game settings. It is loaded from (and saved
+// back to) config.yml in the OS config directory, so players can tweak the
+// game without recompiling.
+type Config struct {
+	Width  float32 `yaml:"width"`
+	Height float32 `yaml:"height"`
+
+	PlayInterval time.Duration `yaml:"playInterval"`
+	ResetTime    time.Duration `yaml:"resetTime"`
+
+	Colors []string `yaml:"colors"`
+
+	Audio bool `yaml:"audio"`
+
+	// Mode selects the GameMode to play, overridden by the -mode flag.
+	Mode string `yaml:"mode"`
+
+	// BestStreaks holds the longest streak of correct rounds achieved so
+	// far, keyed by GameMode.Name().
+	BestStreaks map[string]int `yaml:"bestStreaks"`
+}
+
+// defaultConfig returns the settings the game shipped with before config.yml
+// existed.
+func defaultConfig() Config {
+	return Config{
+		Width:  800,
+		Height: 600,
+
+		PlayInterval: 500 * time.Millisecond,
+		ResetTime:    time.Second,
+
+		Colors: []string{
+			"#00C800", // green
+			"#FF0000", // red
+			"#FFFF00", // yellow
+			"#0080FF", // blue
+		},
+
+		Audio: true,
+
+		Mode: "classic",
+
+		BestStreaks: map[string]int{},
+	}
+}
+
+// configDir returns the directory config.yml lives in, creating it if
+// necessary.
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir = filepath.Join(dir, "simon")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func configPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "config.yml"), nil
+}
+
+// loadConfig reads config.yml, creating it with defaults if it doesn't exist
+// yet.
+func loadConfig() (Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return defaultConfig(), err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		cfg := defaultConfig()
+		return cfg, saveConfig(cfg)
+	} else if err != nil {
+		return defaultConfig(), err
+	}
+
+	cfg := defaultConfig()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return defaultConfig(), err
+	}
+
+	if len(cfg.Colors) == 0 {
+		cfg.Colors = defaultConfig().Colors
+	}
+
+	return cfg, nil
+}
+
+// saveConfig writes cfg to config.yml, overwriting whatever is there.
+func saveConfig(cfg Config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// saveBestStreak updates and persists the longest streak achieved so far for
+// the given mode, without touching the rest of the config.
+func saveBestStreak(cfg Config, mode string, streak int) Config {
+	if cfg.BestStreaks == nil {
+		cfg.BestStreaks = map[string]int{}
+	}
+
+	if streak > cfg.BestStreaks[mode] {
+		cfg.BestStreaks[mode] = streak
+	}
+
+	if err := saveConfig(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "saveConfig:", err)
+	}
+
+	return cfg
+}
+
+// parseColor turns a "#RRGGBB" string from the config file into an opaque
+// color.NRGBA, falling back to white if the string is malformed.
+func parseColor(s string) color.NRGBA {
+	var r, g, b uint8
+
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+
+	return color.NRGBA{R: r, G: g, B: b, A: 255}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+)
+
+// hud is the small diagnostic overlay enabled by -hud. It tracks
+// frames-per-second, time spent inside the last FrameEvent, and how many
+// timers scheduled with afterFunc are still outstanding, since the game
+// fires several nested time.AfterFunc calls per round.
+type hud struct {
+	enabled bool
+
+	frames      int
+	windowStart time.Time
+	fps         float64
+
+	frameDur time.Duration
+
+	timers int32
+}
+
+// frameStart returns the time the caller should later pass to recordFrame.
+func (h *hud) frameStart() time.Time {
+	return time.Now()
+}
+
+// recordFrame updates the fps and frame-time stats for a FrameEvent that
+// began at start.
+func (h *hud) recordFrame(start time.Time) {
+	if !h.enabled {
+		return
+	}
+
+	h.frameDur = time.Since(start)
+	h.frames++
+
+	now := time.Now()
+	if h.windowStart.IsZero() {
+		h.windowStart = now
+	}
+
+	if elapsed := now.Sub(h.windowStart); elapsed >= time.Second {
+		h.fps = float64(h.frames) / elapsed.Seconds()
+		h.frames = 0
+		h.windowStart = now
+	}
+}
+
+// afterFunc wraps time.AfterFunc, tracking f as an outstanding timer until it
+// fires.
+func (h *hud) afterFunc(d time.Duration, f func()) *time.Timer {
+	atomic.AddInt32(&h.timers, 1)
+	return time.AfterFunc(d, func() {
+		atomic.AddInt32(&h.timers, -1)
+		f()
+	})
+}
+
+// Layout draws the HUD text in the top-left corner, or nothing if the HUD is
+// disabled.
+func (h *hud) Layout(gtx C, th *material.Theme) D {
+	if !h.enabled {
+		return D{}
+	}
+
+	text := fmt.Sprintf("%.0f fps  %s/frame  %d timers",
+		h.fps, h.frameDur.Round(time.Microsecond), atomic.LoadInt32(&h.timers))
+
+	return layout.Inset{Top: unit.Dp(4), Left: unit.Dp(4)}.Layout(gtx, material.Caption(th, text).Layout)
+}
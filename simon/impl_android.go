@@ -0,0 +1,69 @@
+//go:build android
+
+package main
+
+/*
+#include <jni.h>
+#include <stdlib.h>
+
+static jobject getVibrator(JNIEnv *env, jobject ctx) {
+	jclass contextClass = (*env)->GetObjectClass(env, ctx);
+	jmethodID getSystemService = (*env)->GetMethodID(env, contextClass,
+		"getSystemService", "(Ljava/lang/String;)Ljava/lang/Object;");
+	jstring service = (*env)->NewStringUTF(env, "vibrator");
+	return (*env)->CallObjectMethod(env, ctx, getSystemService, service);
+}
+
+static void doVibrate(JNIEnv *env, jobject vibrator, jlong ms) {
+	jclass vibratorClass = (*env)->GetObjectClass(env, vibrator);
+	jmethodID vibrate = (*env)->GetMethodID(env, vibratorClass, "vibrate", "(J)V");
+	(*env)->CallVoidMethod(env, vibrator, vibrate, ms);
+}
+
+static jint attach(JavaVM *vm, JNIEnv **env) {
+	return (*vm)->AttachCurrentThread(vm, env, NULL);
+}
+
+static void detach(JavaVM *vm) {
+	(*vm)->DetachCurrentThread(vm);
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"gioui.org/app"
+)
+
+// newWindow creates the game window. Phones don't have a fixed size or
+// resizable chrome, so we just ask for a title and let the OS give us the
+// whole screen.
+func newWindow() *app.Window {
+	return app.NewWindow(
+		app.Title("Simon"),
+	)
+}
+
+// vibrate fires a short haptic pulse through the Android Vibrator service,
+// reached via a small JNI shim. It looks the service up on every call
+// instead of caching the jobject, since Gio gives us no lifecycle hook to
+// release a global reference on.
+func vibrate() {
+	vm := (*C.JavaVM)(unsafe.Pointer(app.JavaVM()))
+	ctx := C.jobject(unsafe.Pointer(app.AppContext()))
+
+	var env *C.JNIEnv
+	if C.attach(vm, &env) != 0 {
+		return
+	}
+	defer C.detach(vm)
+
+	vibrator := C.getVibrator(env, ctx)
+	if vibrator == nil {
+		return
+	}
+
+	const pulseMillis = 50
+	C.doVibrate(env, vibrator, C.jlong(pulseMillis))
+}
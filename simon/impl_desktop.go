@@ -0,0 +1,23 @@
+//go:build (darwin || linux || windows) && !android && !ios
+
+package main
+
+import (
+	"gioui.org/app"
+	"gioui.org/unit"
+)
+
+// newWindow creates the game window. On the desktop the window is fixed at
+// the configured size, since there's no notion of rotating or resizing a
+// phone screen to worry about.
+func newWindow() *app.Window {
+	return app.NewWindow(
+		app.Title("Simon"),
+		app.Size(unit.Px(ww), unit.Px(wh)),
+		app.MinSize(unit.Px(ww), unit.Px(wh)),
+		app.MaxSize(unit.Px(ww), unit.Px(wh)),
+	)
+}
+
+// vibrate is a no-op on the desktop; there's no haptic hardware to drive.
+func vibrate() {}
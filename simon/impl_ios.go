@@ -0,0 +1,20 @@
+//go:build ios
+
+package main
+
+import (
+	"gioui.org/app"
+)
+
+// newWindow creates the game window. Phones don't have a fixed size or
+// resizable chrome, so we just ask for a title and let the OS give us the
+// whole screen.
+func newWindow() *app.Window {
+	return app.NewWindow(
+		app.Title("Simon"),
+	)
+}
+
+// vibrate is a no-op on iOS for now; UIFeedbackGenerator support can be
+// wired in the same way the Android JNI shim is, if it's needed later.
+func vibrate() {}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"flag"
 	"image"
 	"image/color"
 	"log"
+	"math"
 	"math/rand"
 	"os"
 	"time"
@@ -72,6 +74,9 @@ type Sequence struct {
 	list   []int
 	lindex int
 	maxval int
+
+	reverse bool // expect the user's input in reverse order, for reverseMode
+	grow    bool // append to list each round instead of replacing it
 }
 
 func (s *Sequence) Len() int {
@@ -81,18 +86,31 @@ func (s *Sequence) Len() int {
 func (s *Sequence) Reset(add bool) {
 	s.lindex = 0
 	if add {
-		s.list = append(s.list, rand.Intn(s.maxval))
+		next := rand.Intn(s.maxval)
+		if s.grow {
+			s.list = append(s.list, next)
+		} else {
+			s.list = []int{next}
+		}
 	}
 }
 
-func (s *Sequence) Next() int {
-	if s.lindex == len(s.list) {
+// Next returns the next value in the sequence, advancing the read position.
+// reverse selects which end of the list to read from: simon always plays
+// the sequence back in the order it was recorded (reverse == false), while
+// checking the user's input against it honors s.reverse, so reverseMode can
+// require the sequence back in the opposite order it was shown in.
+func (s *Sequence) Next(reverse bool) int {
+	if !s.HasNext() {
 		return -1
 	}
 
-	curr := s.list[s.lindex]
+	idx := s.lindex
+	if reverse {
+		idx = len(s.list) - 1 - s.lindex
+	}
 	s.lindex++
-	return curr
+	return s.list[idx]
 }
 
 func (s *Sequence) HasNext() bool {
@@ -107,29 +125,87 @@ var (
 	playInterval = 500 * time.Millisecond
 	resetTime    = time.Second
 
-	pads = []Pad{
-		{new(widget.Clickable), "1", color.NRGBA{A: 255, R: 0, G: 200, B: 0}},   // green
-		{new(widget.Clickable), "2", color.NRGBA{A: 255, R: 255, G: 0, B: 0}},   // red
-		{new(widget.Clickable), "3", color.NRGBA{A: 255, R: 255, G: 255, B: 0}}, // yellow
-		{new(widget.Clickable), "4", color.NRGBA{A: 255, R: 0, G: 128, B: 255}}, // blue
-	}
+	pads []Pad
+
+	sequence Sequence
 
-	sequence = Sequence{maxval: 4}
+	cfg Config
 )
 
+// maxPads is the most pads padsFromConfig will generate. Pads are selectable
+// by keyboard digit, so there's no usable label beyond '9'.
+const maxPads = 9
+
+// padsFromConfig builds the pad set from the configured color palette, one
+// pad per color, capped at maxPads and never empty.
+func padsFromConfig(cfg Config) []Pad {
+	colors := cfg.Colors
+	if len(colors) == 0 {
+		colors = defaultConfig().Colors
+	}
+	if len(colors) > maxPads {
+		log.Printf("config: %d colors configured, using the first %d", len(colors), maxPads)
+		colors = colors[:maxPads]
+	}
+
+	pads := make([]Pad, len(colors))
+	for i, c := range colors {
+		pads[i] = Pad{new(widget.Clickable), string(rune('1' + i)), parseColor(c)}
+	}
+
+	return pads
+}
+
+// gridCols picks a column count that lays n pads out in as square a grid as
+// possible.
+func gridCols(n int) int {
+	return int(math.Ceil(math.Sqrt(float64(n))))
+}
+
 func main() {
+	modeFlag := flag.String("mode", "", "game mode: classic, speedup, reverse, endless (default: config, then classic)")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to this file")
+	memProfile := flag.String("memprofile", "", "write a heap profile to this file")
+	hudFlag := flag.Bool("hud", false, "overlay an fps/frame-time/timer HUD")
+	flag.Parse()
+
 	rand.Seed(time.Now().Unix())
 
-	audioInit()
+	var err error
+	cfg, err = loadConfig()
+	if err != nil {
+		log.Println("loadConfig:", err)
+	}
+
+	modeName := *modeFlag
+	if modeName == "" {
+		modeName = cfg.Mode
+	}
+	mode := modeByName(modeName)
+
+	ww, wh = cfg.Width, cfg.Height
+	playInterval = cfg.PlayInterval
+	resetTime = cfg.ResetTime
+	pads = padsFromConfig(cfg)
+	sequence = Sequence{maxval: len(pads), reverse: mode.Reversed(), grow: mode.GrowsSequence()}
+
+	if cfg.Audio {
+		audioInit()
+	}
 
 	go func() {
-		w := app.NewWindow(
-			app.Title("Simon"),
-			app.Size(unit.Px(ww), unit.Px(wh)),
-			app.MinSize(unit.Px(ww), unit.Px(wh)),
-			app.MaxSize(unit.Px(ww), unit.Px(wh)),
-		)
-		if err := loop(w); err != nil {
+		w := newWindow()
+
+		stopProfiling, err := startProfiling(*cpuProfile, *memProfile)
+		if err != nil {
+			log.Println("startProfiling:", err)
+		}
+
+		h := &hud{enabled: *hudFlag}
+
+		err = loop(w, mode, h)
+		stopProfiling()
+		if err != nil {
 			log.Fatal(err)
 		}
 		os.Exit(0)
@@ -137,15 +213,16 @@ func main() {
 	app.Main()
 }
 
-func loop(w *app.Window) error {
+func loop(w *app.Window, mode GameMode, h *hud) error {
 	var ops op.Ops
 
 	th := material.NewTheme(gofont.Collection())
 
-	grid := outlay.Grid{Num: 2, Axis: layout.Horizontal}
+	grid := outlay.Grid{Num: gridCols(len(pads)), Axis: layout.Horizontal}
 	simonPlay := true
 	terminating := false
 	selected := -1
+	streak := 0 // rounds completed correctly so far; sequence.Len() doesn't track this once mode.GrowsSequence() is false
 
 	log.Println("simon play...")
 	sequence.Reset(true)
@@ -157,16 +234,18 @@ func loop(w *app.Window) error {
 			return e.Err
 
 		case system.FrameEvent:
+			frameStart := h.frameStart()
+
 			gtx := layout.NewContext(&ops, e)
 			if simonPlay || terminating {
 				gtx = gtx.Disabled()
 			}
 
 			if simonPlay { // the FrameEvent is from invalidate
-				simon := sequence.Next()
+				simon := sequence.Next(false) // simon always plays the sequence back in recorded order
 				if simon >= 0 {
 					selected = simon
-					time.AfterFunc(playInterval, w.Invalidate)
+					h.afterFunc(mode.Interval(sequence.Len()-1), w.Invalidate)
 				} else {
 					log.Println("user play...")
 					simonPlay = false
@@ -176,9 +255,12 @@ func loop(w *app.Window) error {
 
 			user := -1
 
+			cols := gridCols(len(pads))
+			rows := (len(pads) + cols - 1) / cols
+
 			grid.Layout(gtx, len(pads), func(gtx C, i int) D {
-				gtx.Constraints.Max.X = gtx.Constraints.Max.X / 2
-				gtx.Constraints.Max.Y = int(wh) / 2
+				gtx.Constraints.Max.X = gtx.Constraints.Max.X / cols
+				gtx.Constraints.Max.Y = e.Size.Y / rows
 
 				pad := pads[i]
 
@@ -191,6 +273,7 @@ func loop(w *app.Window) error {
 
 							if ev.Type == pointer.Press {
 								user = i
+								vibrate()
 							} else if ev.Type == pointer.Release {
 								user = -1
 							}
@@ -200,7 +283,7 @@ func loop(w *app.Window) error {
 					}
 				}
 
-				if selected == i && !audioPlaying {
+				if cfg.Audio && selected == i && !audioPlaying {
 					log.Println("play", i+1)
 					audioPlay(selected)
 				}
@@ -218,24 +301,28 @@ func loop(w *app.Window) error {
 			if !simonPlay && user >= 0 {
 				// there are FrameEvents that are not from button clicks
 				// if user >= 0 it was a button click
-				simon := sequence.Next()
+				simon := sequence.Next(sequence.reverse) // check against reversed order for reverseMode
 				if simon >= 0 {
 					log.Println("simon", simon+1, "user", user+1)
 					if simon != user {
 						terminating = true
 
-						time.AfterFunc(playInterval, func() {
-							log.Println("Longest correct sequence:", sequence.Len()-1)
-							audioPlay(audioBuzz)
+						h.afterFunc(playInterval, func() {
+							log.Println("Longest correct sequence:", streak)
+							cfg = saveBestStreak(cfg, mode.Name(), streak)
+							if cfg.Audio {
+								audioPlay(audioBuzz)
+							}
 
-							time.AfterFunc(resetTime, func() {
+							h.afterFunc(resetTime, func() {
 								w.Close()
 							})
 						})
 					}
 				}
 				if !terminating && !sequence.HasNext() {
-					time.AfterFunc(resetTime, func() {
+					streak++
+					h.afterFunc(resetTime, func() {
 						log.Println("simon play...")
 						simonPlay = true
 						sequence.Reset(true)
@@ -244,19 +331,13 @@ func loop(w *app.Window) error {
 				}
 			}
 
+			cellW := e.Size.X / cols
+			cellH := e.Size.Y / rows
+
 			for i := 0; i < len(pads); i++ {
-				var pos image.Rectangle
-
-				switch i {
-				case 0:
-					pos = image.Rect(0, 0, int(ww/2), int(wh/2))
-				case 1:
-					pos = image.Rect(int(ww/2), 0, int(ww), int(wh/2))
-				case 2:
-					pos = image.Rect(0, int(wh/2), int(ww/2), int(wh))
-				case 3:
-					pos = image.Rect(int(ww/2), int(wh/2), int(ww), int(wh))
-				}
+				col := i % cols
+				row := i / cols
+				pos := image.Rect(col*cellW, row*cellH, (col+1)*cellW, (row+1)*cellH)
 
 				// Register to listen for pointer events.
 				pr := pointer.Rect(pos).Push(gtx.Ops)
@@ -264,20 +345,29 @@ func loop(w *app.Window) error {
 				pr.Pop()
 			}
 
+			h.Layout(gtx, th)
+
 			e.Frame(gtx.Ops)
 			selected = -1
 
+			h.recordFrame(frameStart)
+
 		case key.Event:
 			if e.State == key.Press {
 				switch e.Name {
-				case "1", "2", "3", "4":
-					if !simonPlay {
-						selected = int(e.Name[0] - '1')
-						w.Invalidate()
-					}
-
 				case "X", "Q":
 					w.Close()
+
+				default:
+					if !simonPlay {
+						for i, pad := range pads {
+							if e.Name == key.Name(pad.label) {
+								selected = i
+								w.Invalidate()
+								break
+							}
+						}
+					}
 				}
 			} else {
 				selected = -1
@@ -0,0 +1,78 @@
+package main
+
+import "testing"
+
+func TestSequencePlaybackOrder(t *testing.T) {
+	s := &Sequence{maxval: 4, grow: true}
+	s.list = []int{1, 2, 3}
+	s.lindex = 0
+
+	for i, want := range []int{1, 2, 3} {
+		if got := s.Next(false); got != want {
+			t.Fatalf("Next(false) #%d = %d, want %d", i, got, want)
+		}
+	}
+	if got := s.Next(false); got != -1 {
+		t.Fatalf("Next(false) past the end = %d, want -1", got)
+	}
+}
+
+func TestSequenceReversedCheckOrder(t *testing.T) {
+	s := &Sequence{maxval: 4, grow: true}
+	s.list = []int{1, 2, 3}
+	s.lindex = 0
+
+	for i, want := range []int{3, 2, 1} {
+		if got := s.Next(true); got != want {
+			t.Fatalf("Next(true) #%d = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestSequenceResetGrow(t *testing.T) {
+	s := &Sequence{maxval: 1, grow: true}
+
+	s.Reset(true)
+	s.Reset(true)
+	if got := s.Len(); got != 2 {
+		t.Fatalf("Len() after two growing resets = %d, want 2", got)
+	}
+}
+
+func TestSequenceResetNoGrow(t *testing.T) {
+	s := &Sequence{maxval: 1, grow: false}
+
+	s.Reset(true)
+	s.Reset(true)
+	if got := s.Len(); got != 1 {
+		t.Fatalf("Len() after two non-growing resets = %d, want 1", got)
+	}
+}
+
+func TestPadsFromConfigEmptyColorsFallsBackToDefault(t *testing.T) {
+	pads := padsFromConfig(Config{Colors: nil})
+	if len(pads) == 0 {
+		t.Fatal("padsFromConfig with no colors returned no pads")
+	}
+}
+
+func TestPadsFromConfigCapsAtMaxPads(t *testing.T) {
+	colors := make([]string, maxPads+5)
+	for i := range colors {
+		colors[i] = "#000000"
+	}
+
+	pads := padsFromConfig(Config{Colors: colors})
+	if len(pads) != maxPads {
+		t.Fatalf("len(pads) = %d, want %d", len(pads), maxPads)
+	}
+}
+
+func TestReverseModeReverses(t *testing.T) {
+	if !(reverseMode{}).Reversed() {
+		t.Fatal("reverseMode.Reversed() = false, want true")
+	}
+	if (classicMode{}).Reversed() {
+		t.Fatal("classicMode.Reversed() = true, want false")
+	}
+}
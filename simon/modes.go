@@ -0,0 +1,92 @@
+package main
+
+import "time"
+
+// GameMode controls the rules a round is played and judged under: how long
+// a pad stays lit, whether the sequence must be replayed backwards, and
+// whether the sequence keeps growing (classic memorization) or is judged one
+// pad at a time (reaction only).
+type GameMode interface {
+	// Name identifies the mode; it's also the key its high score is stored
+	// under in the config file.
+	Name() string
+
+	// Interval returns the display interval for the given round (0-based).
+	Interval(round int) time.Duration
+
+	// Reversed reports whether the user must repeat the sequence backwards.
+	Reversed() bool
+
+	// GrowsSequence reports whether each round appends to the sequence
+	// (classic memorization) or replaces it (reaction-only judging).
+	GrowsSequence() bool
+}
+
+// classicMode is the original game: the sequence grows by one pad every
+// round, played back at a fixed interval.
+type classicMode struct{}
+
+func (classicMode) Name() string                     { return "classic" }
+func (classicMode) Interval(round int) time.Duration { return playInterval }
+func (classicMode) Reversed() bool                   { return false }
+func (classicMode) GrowsSequence() bool              { return true }
+
+// speedUpMode plays classic-style but shortens the display interval every
+// round by factor, down to floor.
+type speedUpMode struct {
+	factor float64
+	floor  time.Duration
+}
+
+func (speedUpMode) Name() string { return "speedup" }
+
+func (m speedUpMode) Interval(round int) time.Duration {
+	d := playInterval
+	for i := 0; i < round; i++ {
+		d = time.Duration(float64(d) * m.factor)
+	}
+	if d < m.floor {
+		d = m.floor
+	}
+	return d
+}
+
+func (speedUpMode) Reversed() bool      { return false }
+func (speedUpMode) GrowsSequence() bool { return true }
+
+// reverseMode plays classic-style, but the user must repeat the sequence
+// backwards.
+type reverseMode struct{}
+
+func (reverseMode) Name() string                     { return "reverse" }
+func (reverseMode) Interval(round int) time.Duration { return playInterval }
+func (reverseMode) Reversed() bool                   { return true }
+func (reverseMode) GrowsSequence() bool              { return true }
+
+// endlessMode drops memorization entirely: every round is a single random
+// pad judged on its own, so the score is the length of the current run of
+// correct reactions rather than a memorized sequence.
+type endlessMode struct{}
+
+func (endlessMode) Name() string                     { return "endless" }
+func (endlessMode) Interval(round int) time.Duration { return playInterval }
+func (endlessMode) Reversed() bool                   { return false }
+func (endlessMode) GrowsSequence() bool              { return false }
+
+// gameModes lists the modes selectable via the -mode flag or the config
+// file's mode setting.
+var gameModes = map[string]GameMode{
+	"classic": classicMode{},
+	"speedup": speedUpMode{factor: 0.9, floor: 200 * time.Millisecond},
+	"reverse": reverseMode{},
+	"endless": endlessMode{},
+}
+
+// modeByName looks up a mode by name, falling back to classic for an
+// unrecognized or empty name.
+func modeByName(name string) GameMode {
+	if m, ok := gameModes[name]; ok {
+		return m
+	}
+	return classicMode{}
+}
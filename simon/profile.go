@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log"
+	"os"
+	"runtime/pprof"
+)
+
+// profiler owns the files backing -cpuprofile/-memprofile so they can be
+// closed (and the heap profile written) once before the process exits.
+type profiler struct {
+	cpuFile *os.File
+	memPath string
+}
+
+// startProfiling opens the file named by cpuPath and starts CPU profiling;
+// the file named by memPath is only created by stop, when the heap profile is
+// written. Either one is skipped when its path is empty. The returned stop
+// func must run before the process exits so both profiles are flushed to disk.
+func startProfiling(cpuPath, memPath string) (stop func(), err error) {
+	p := &profiler{memPath: memPath}
+
+	if cpuPath != "" {
+		f, err := os.Create(cpuPath)
+		if err != nil {
+			return func() {}, err
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return func() {}, err
+		}
+
+		p.cpuFile = f
+	}
+
+	return p.stop, nil
+}
+
+func (p *profiler) stop() {
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		if err := p.cpuFile.Close(); err != nil {
+			log.Println("cpuprofile:", err)
+		}
+	}
+
+	if p.memPath != "" {
+		f, err := os.Create(p.memPath)
+		if err != nil {
+			log.Println("memprofile:", err)
+			return
+		}
+		defer f.Close()
+
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			log.Println("memprofile:", err)
+		}
+	}
+}